@@ -5,51 +5,80 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/docker/infrakit/discovery"
 	group_plugin "github.com/docker/infrakit/rpc/group"
 	"github.com/docker/infrakit/spi/group"
+	"github.com/docker/infrakit/store"
 	"github.com/spf13/cobra"
 )
 
 const (
 	// DefaultGroupPluginName specifies the default name of the group plugin if name flag isn't specified.
 	DefaultGroupPluginName = "group"
+
+	// DefaultStoreDir is where group.Spec history is kept if --store-dir isn't specified.
+	DefaultStoreDir = "~/.infrakit/groups"
+
+	// DefaultLogsSince is how far back `group logs` looks for backlog when --since isn't
+	// specified, so that running it with no flags shows something useful rather than
+	// silently blocking on events that haven't happened yet.
+	DefaultLogsSince = 10 * time.Minute
 )
 
 func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 
 	name := DefaultGroupPluginName
+	storeDir := DefaultStoreDir
 	var groupPlugin group.Plugin
+	var specStore store.Store
+
+	// connectGroupPlugin lazily resolves and dials the group plugin.  It's called from the
+	// RunE of commands that actually talk to the plugin, rather than from
+	// PersistentPreRunE, so that store-only commands (history, diff) work even when no group
+	// plugin is running or discoverable.
+	connectGroupPlugin := func() error {
+		if groupPlugin != nil {
+			return nil
+		}
+
+		endpoint, err := plugins().Find(name)
+		if err != nil {
+			return err
+		}
+
+		groupPlugin, err = group_plugin.NewClient(endpoint.Protocol, endpoint.Address)
+		return err
+	}
 
 	cmd := &cobra.Command{
 		Use:   "group",
 		Short: "Access group plugin",
 		PersistentPreRunE: func(c *cobra.Command, args []string) error {
-
-			endpoint, err := plugins().Find(name)
-			if err != nil {
-				return err
-			}
-
-			groupPlugin, err = group_plugin.NewClient(endpoint.Protocol, endpoint.Address)
-			if err != nil {
-				return err
-			}
-
-			return nil
+			var err error
+			specStore, err = store.NewFileStore(expandPath(storeDir))
+			return err
 		},
 	}
 	cmd.PersistentFlags().StringVar(&name, "name", name, "Name of plugin")
+	cmd.PersistentFlags().StringVar(&storeDir, "store-dir", storeDir, "Directory for group.Spec history")
 
+	var watchDryRun bool
+	var watchOutput string
 	watch := &cobra.Command{
 		Use:   "watch <group configuration>",
 		Short: "watch a group",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -67,6 +96,14 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 				return err
 			}
 
+			if watchDryRun {
+				return planAndRender(groupPlugin, spec, watchOutput)
+			}
+
+			if _, err := specStore.Save(spec); err != nil {
+				return err
+			}
+
 			err = groupPlugin.WatchGroup(spec)
 			if err == nil {
 				fmt.Println("watching", spec.ID)
@@ -74,12 +111,16 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 			return err
 		},
 	}
+	watch.Flags().BoolVar(&watchDryRun, "dry-run", false, "Show the plan for watching this group without submitting it")
+	watch.Flags().StringVarP(&watchOutput, "output", "o", "table", "Output format for --dry-run: table or json")
 
 	unwatch := &cobra.Command{
 		Use:   "unwatch <group ID>",
 		Short: "unwatch a group",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -101,7 +142,9 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 		Use:   "inspect <group ID>",
 		Short: "inspect a group",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -139,7 +182,9 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 		Use:   "describe-update <group configuration file>",
 		Short: "describe the steps to perform an update",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -165,11 +210,16 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 		},
 	}
 
+	var follow bool
+	var updateDryRun bool
+	var updateOutput string
 	update := &cobra.Command{
 		Use:   "update [group configuration]",
 		Short: "update a group",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -187,20 +237,36 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 				return err
 			}
 
-			// TODO - make this not block, but how to get status?
-			err = groupPlugin.UpdateGroup(spec)
-			if err == nil {
-				fmt.Println("update", spec.ID, "completed")
+			if updateDryRun {
+				return planAndRender(groupPlugin, spec, updateOutput)
 			}
-			return err
+
+			if _, err := specStore.Save(spec); err != nil {
+				return err
+			}
+
+			if !follow {
+				err = groupPlugin.UpdateGroup(spec)
+				if err == nil {
+					fmt.Println("update", spec.ID, "completed")
+				}
+				return err
+			}
+
+			return followUpdate(groupPlugin, spec)
 		},
 	}
+	update.Flags().BoolVar(&follow, "follow", false, "Start the update and stream progress until it completes")
+	update.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show the plan for this update without submitting it")
+	update.Flags().StringVarP(&updateOutput, "output", "o", "table", "Output format for --dry-run: table or json")
 
 	stop := &cobra.Command{
 		Use:   "stop-update <group ID>",
 		Short: "stop updating a group",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -221,7 +287,9 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 		Use:   "destroy <group ID>",
 		Short: "destroy a group",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			if len(args) != 1 {
 				cmd.Usage()
@@ -241,7 +309,9 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 		Use:   "ls",
 		Short: "list groups",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			assertNotNil("no plugin", groupPlugin)
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
 
 			groups, err := groupPlugin.DescribeGroups()
 			if err == nil {
@@ -258,7 +328,234 @@ func groupPluginCommand(plugins func() discovery.Plugins) *cobra.Command {
 	}
 	describeGroups.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print rows without column headers")
 
-	cmd.AddCommand(watch, unwatch, inspect, describe, update, stop, destroy, describeGroups)
+	history := &cobra.Command{
+		Use:   "history <group ID>",
+		Short: "show the revision history of a group's configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			groupID := group.ID(args[0])
+			manifests, err := specStore.History(groupID)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%-10s\t%-s\n", "REV", "DIGEST")
+			for _, manifest := range manifests {
+				fmt.Printf("%-10d\t%-s\n", manifest.Sequence, manifest.Digest)
+			}
+			return nil
+		},
+	}
+
+	diff := &cobra.Command{
+		Use:   "diff <group ID> <rev1> <rev2>",
+		Short: "show the diff between two revisions of a group's configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			groupID := group.ID(args[0])
+
+			from, err := specStore.Get(groupID, args[1])
+			if err != nil {
+				return err
+			}
+
+			to, err := specStore.Get(groupID, args[2])
+			if err != nil {
+				return err
+			}
+
+			out, err := store.Diff(from, to)
+			if err == nil {
+				fmt.Println(out)
+			}
+			return err
+		},
+	}
+
+	rollback := &cobra.Command{
+		Use:   "rollback <group ID> <rev>",
+		Short: "re-submit a prior revision of a group's configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
+
+			if len(args) != 2 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			groupID := group.ID(args[0])
+
+			manifest, err := specStore.Get(groupID, args[1])
+			if err != nil {
+				return err
+			}
+
+			if _, err := specStore.Save(manifest.Spec); err != nil {
+				return err
+			}
+
+			err = groupPlugin.UpdateGroup(manifest.Spec)
+			if err == nil {
+				fmt.Println("rolled back", groupID, "to", manifest.Digest)
+			}
+			return err
+		},
+	}
+
+	var logsFollow bool
+	since := DefaultLogsSince
+	logs := &cobra.Command{
+		Use:   "logs <group ID>",
+		Short: "stream controller decisions and instance provisioning output for a group",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
+
+			if len(args) != 1 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			groupID := group.ID(args[0])
+
+			opts := group.EventsOptions{Follow: logsFollow}
+			if since > 0 {
+				opts.Since = time.Now().Add(-since)
+			}
+
+			events, err := groupPlugin.GroupEvents(groupID, opts)
+			if err != nil {
+				return err
+			}
+
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt)
+			defer signal.Stop(interrupt)
+
+			for {
+				select {
+				case event, open := <-events:
+					if !open {
+						return nil
+					}
+
+					instanceID := event.InstanceID
+					if instanceID == "" {
+						instanceID = "  -   "
+					}
+					fmt.Printf("%s\t%-30s\t%-20s\t%s\n",
+						event.Time.Format(time.RFC3339), instanceID, event.Type, event.Message)
+
+				case <-interrupt:
+					return nil
+				}
+			}
+		},
+	}
+	logs.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Stream new events as they occur")
+	logs.Flags().DurationVar(&since, "since", since, "Show events since this long ago, e.g. 15m")
+
+	cmd.AddCommand(watch, unwatch, inspect, describe, update, stop, destroy, describeGroups,
+		history, diff, rollback, logs)
 
 	return cmd
 }
+
+// expandPath resolves a leading "~" in path to the current user's home directory.
+func expandPath(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+
+	usr, err := user.Current()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(usr.HomeDir, strings.TrimPrefix(path, "~"))
+}
+
+// planAndRender fetches a Plan for spec and renders it as a table or, when output is "json", as
+// indented JSON, without submitting the spec to the plugin.
+func planAndRender(plugin group.Plugin, spec group.Spec, output string) error {
+	plan, err := plugin.PlanUpdate(spec)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json":
+		buff, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buff))
+
+	default:
+		fmt.Printf("%-30s\t%-10s\t%-s\n", "INSTANCE", "CHANGE", "REASON")
+		for _, change := range plan.Changes {
+			instanceID := change.InstanceID
+			if instanceID == "" {
+				instanceID = "  -   "
+			}
+			fmt.Printf("%-30s\t%-10s\t%-s\n", instanceID, change.Change, change.Reason)
+		}
+	}
+
+	return nil
+}
+
+// followUpdate starts an asynchronous update and renders a live table of per-instance progress
+// events until the update reaches a terminal state.  An interrupt (Ctrl-C) stops the update on
+// the plugin before returning.
+func followUpdate(plugin group.Plugin, spec group.Spec) error {
+	updateID, err := plugin.UpdateGroupAsync(spec)
+	if err != nil {
+		return err
+	}
+
+	events, err := plugin.WatchUpdate(updateID)
+	if err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	fmt.Printf("%-30s\t%-15s\t%-s\n", "INSTANCE", "EVENT", "MESSAGE")
+
+	for {
+		select {
+		case event, open := <-events:
+			if !open {
+				return nil
+			}
+
+			instanceID := event.InstanceID
+			if instanceID == "" {
+				instanceID = "  -   "
+			}
+			fmt.Printf("%-30s\t%-15s\t%-s\n", instanceID, event.Type, event.Message)
+
+			if event.Type == group.UpdateEventFailed {
+				return fmt.Errorf("update %s failed: %s", spec.ID, event.Error)
+			}
+
+		case <-interrupt:
+			fmt.Println("stopping update", spec.ID)
+			return plugin.StopUpdate(spec.ID)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/docker/infrakit/discovery"
+	group_plugin "github.com/docker/infrakit/rpc/group"
+	resource_plugin "github.com/docker/infrakit/rpc/resource"
+	"github.com/docker/infrakit/spi/group"
+	"github.com/docker/infrakit/spi/resource"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// DefaultResourcePluginName specifies the default name of the resource plugin if name flag isn't specified.
+	DefaultResourcePluginName = "resource"
+)
+
+func resourcePluginCommand(plugins func() discovery.Plugins) *cobra.Command {
+
+	name := DefaultResourcePluginName
+	groupPluginName := DefaultGroupPluginName
+	var resourcePlugin resource.Plugin
+	var groupPlugin group.Plugin
+
+	cmd := &cobra.Command{
+		Use:   "resource",
+		Short: "Access resource plugin",
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+
+			endpoint, err := plugins().Find(name)
+			if err != nil {
+				return err
+			}
+
+			resourcePlugin, err = resource_plugin.NewClient(endpoint.Protocol, endpoint.Address)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVar(&name, "name", name, "Name of plugin")
+	cmd.PersistentFlags().StringVar(&groupPluginName, "group-name", groupPluginName,
+		"Name of the group plugin to check Spec.Depends against before destroying a resource")
+
+	// connectGroupPlugin lazily resolves and dials the group plugin.  It's only needed by
+	// destroy, so commands that don't tear anything down work even when no group plugin is
+	// running or discoverable.
+	connectGroupPlugin := func() error {
+		if groupPlugin != nil {
+			return nil
+		}
+
+		endpoint, err := plugins().Find(groupPluginName)
+		if err != nil {
+			return err
+		}
+
+		groupPlugin, err = group_plugin.NewClient(endpoint.Protocol, endpoint.Address)
+		return err
+	}
+
+	commit := &cobra.Command{
+		Use:   "commit <resource configuration>",
+		Short: "commit a resource",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assertNotNil("no plugin", resourcePlugin)
+
+			if len(args) != 1 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			buff, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			spec := resource.Spec{}
+			if err := json.Unmarshal(buff, &spec); err != nil {
+				return err
+			}
+
+			id, err := resourcePlugin.Commit(spec)
+			if err == nil {
+				fmt.Println("committed", id)
+			}
+			return err
+		},
+	}
+
+	var quiet bool
+	ls := &cobra.Command{
+		Use:   "ls",
+		Short: "list resources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assertNotNil("no plugin", resourcePlugin)
+
+			descriptions, err := resourcePlugin.DescribeAll()
+			if err == nil {
+				if !quiet {
+					fmt.Printf("%-30s\t%-s\n", "ID", "STATE")
+				}
+				for _, d := range descriptions {
+					fmt.Printf("%-30s\t%-s\n", d.ID, d.State)
+				}
+			}
+			return err
+		},
+	}
+	ls.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print rows without column headers")
+
+	destroy := &cobra.Command{
+		Use:   "destroy <resource ID>",
+		Short: "destroy a resource",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assertNotNil("no plugin", resourcePlugin)
+
+			if len(args) != 1 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			resourceID := resource.ID(args[0])
+
+			if err := connectGroupPlugin(); err != nil {
+				return err
+			}
+
+			groups, err := groupPlugin.DescribeGroups()
+			if err != nil {
+				return err
+			}
+			for _, spec := range groups {
+				for _, dep := range spec.Depends {
+					if dep.ID == resourceID {
+						return fmt.Errorf("resource %s is still depended on by watched group %s, refusing to destroy",
+							resourceID, spec.ID)
+					}
+				}
+			}
+
+			err = resourcePlugin.Destroy(resourceID)
+			if err == nil {
+				fmt.Println("destroy", resourceID, "initiated")
+			}
+			return err
+		},
+	}
+
+	cmd.AddCommand(commit, ls, destroy)
+
+	return cmd
+}
@@ -0,0 +1,178 @@
+package group
+
+import (
+	"net/rpc"
+	"time"
+
+	"github.com/docker/infrakit/spi/group"
+)
+
+// NewClient returns a group.Plugin backed by a group plugin listening at the given address.
+func NewClient(protocol, address string) (group.Plugin, error) {
+	rpcClient, err := rpc.Dial(protocol, address)
+	if err != nil {
+		return nil, err
+	}
+	return &client{client: rpcClient}, nil
+}
+
+type client struct {
+	client *rpc.Client
+}
+
+func (c client) WatchGroup(grp group.Spec) error {
+	req := WatchGroupRequest{Spec: grp}
+	resp := WatchGroupResponse{}
+	return c.client.Call("Group.WatchGroup", req, &resp)
+}
+
+func (c client) UnwatchGroup(id group.ID) error {
+	req := UnwatchGroupRequest{ID: id}
+	resp := UnwatchGroupResponse{}
+	return c.client.Call("Group.UnwatchGroup", req, &resp)
+}
+
+func (c client) InspectGroup(id group.ID) (group.Description, error) {
+	req := InspectGroupRequest{ID: id}
+	resp := InspectGroupResponse{}
+	if err := c.client.Call("Group.InspectGroup", req, &resp); err != nil {
+		return group.Description{}, err
+	}
+	return resp.Description, nil
+}
+
+func (c client) DescribeUpdate(updated group.Spec) (string, error) {
+	req := DescribeUpdateRequest{Spec: updated}
+	resp := DescribeUpdateResponse{}
+	if err := c.client.Call("Group.DescribeUpdate", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.Plan, nil
+}
+
+func (c client) PlanUpdate(updated group.Spec) (group.Plan, error) {
+	req := PlanUpdateRequest{Spec: updated}
+	resp := PlanUpdateResponse{}
+	if err := c.client.Call("Group.PlanUpdate", req, &resp); err != nil {
+		return group.Plan{}, err
+	}
+	return resp.Plan, nil
+}
+
+func (c client) UpdateGroup(updated group.Spec) error {
+	req := UpdateGroupRequest{Spec: updated}
+	resp := UpdateGroupResponse{}
+	return c.client.Call("Group.UpdateGroup", req, &resp)
+}
+
+func (c client) StopUpdate(id group.ID) error {
+	req := StopUpdateRequest{ID: id}
+	resp := StopUpdateResponse{}
+	return c.client.Call("Group.StopUpdate", req, &resp)
+}
+
+func (c client) DestroyGroup(id group.ID) error {
+	req := DestroyGroupRequest{ID: id}
+	resp := DestroyGroupResponse{}
+	return c.client.Call("Group.DestroyGroup", req, &resp)
+}
+
+func (c client) DescribeGroups() ([]group.Spec, error) {
+	req := DescribeGroupsRequest{}
+	resp := DescribeGroupsResponse{}
+	if err := c.client.Call("Group.DescribeGroups", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// UpdateGroupAsync starts an update of the group and returns immediately with an identifier
+// that can be used to watch the update's progress via WatchUpdate.
+func (c client) UpdateGroupAsync(updated group.Spec) (string, error) {
+	req := UpdateGroupAsyncRequest{Spec: updated}
+	resp := UpdateGroupAsyncResponse{}
+	if err := c.client.Call("Group.UpdateGroupAsync", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.UpdateID, nil
+}
+
+// WatchUpdate streams UpdateEvents for the given update ID until the update finishes, fails,
+// or is stopped.  The returned channel is closed when no further events will be sent.
+func (c client) WatchUpdate(updateID string) (<-chan group.UpdateEvent, error) {
+	events := make(chan group.UpdateEvent)
+
+	go func() {
+		defer close(events)
+
+		cursor := 0
+		for {
+			req := PollUpdateRequest{UpdateID: updateID, Since: cursor}
+			resp := PollUpdateResponse{}
+
+			if err := c.client.Call("Group.PollUpdate", req, &resp); err != nil {
+				events <- group.UpdateEvent{
+					Type:  group.UpdateEventFailed,
+					Error: err.Error(),
+				}
+				return
+			}
+
+			for _, event := range resp.Events {
+				events <- event
+				cursor++
+			}
+
+			if resp.Done {
+				return
+			}
+
+			time.Sleep(500 * time.Millisecond)
+		}
+	}()
+
+	return events, nil
+}
+
+// GroupEvents streams convergence decisions and per-instance provisioning activity for a group.
+func (c client) GroupEvents(id group.ID, opts group.EventsOptions) (<-chan group.Event, error) {
+	startReq := GroupEventsRequest{ID: id, Opts: opts}
+	startResp := GroupEventsResponse{}
+	if err := c.client.Call("Group.GroupEvents", startReq, &startResp); err != nil {
+		return nil, err
+	}
+
+	events := make(chan group.Event)
+
+	go func() {
+		defer close(events)
+
+		cursor := 0
+		for {
+			req := PollEventsRequest{StreamID: startResp.StreamID, Since: cursor}
+			resp := PollEventsResponse{}
+
+			if err := c.client.Call("Group.PollEvents", req, &resp); err != nil {
+				events <- group.Event{
+					GroupID: id,
+					Type:    group.EventInstanceError,
+					Error:   err.Error(),
+				}
+				return
+			}
+
+			for _, event := range resp.Events {
+				events <- event
+				cursor++
+			}
+
+			if resp.Done {
+				return
+			}
+
+			time.Sleep(500 * time.Millisecond)
+		}
+	}()
+
+	return events, nil
+}
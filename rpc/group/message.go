@@ -0,0 +1,135 @@
+package group
+
+import "github.com/docker/infrakit/spi/group"
+
+// WatchGroupRequest is the rpc wrapper for the WatchGroup method.
+type WatchGroupRequest struct {
+	Spec group.Spec
+}
+
+// WatchGroupResponse is the rpc wrapper for the WatchGroup method.
+type WatchGroupResponse struct {
+}
+
+// UnwatchGroupRequest is the rpc wrapper for the UnwatchGroup method.
+type UnwatchGroupRequest struct {
+	ID group.ID
+}
+
+// UnwatchGroupResponse is the rpc wrapper for the UnwatchGroup method.
+type UnwatchGroupResponse struct {
+}
+
+// InspectGroupRequest is the rpc wrapper for the InspectGroup method.
+type InspectGroupRequest struct {
+	ID group.ID
+}
+
+// InspectGroupResponse is the rpc wrapper for the InspectGroup method.
+type InspectGroupResponse struct {
+	Description group.Description
+}
+
+// DescribeUpdateRequest is the rpc wrapper for the DescribeUpdate method.
+type DescribeUpdateRequest struct {
+	Spec group.Spec
+}
+
+// DescribeUpdateResponse is the rpc wrapper for the DescribeUpdate method.
+type DescribeUpdateResponse struct {
+	Plan string
+}
+
+// PlanUpdateRequest is the rpc wrapper for the PlanUpdate method.
+type PlanUpdateRequest struct {
+	Spec group.Spec
+}
+
+// PlanUpdateResponse is the rpc wrapper for the PlanUpdate method.
+type PlanUpdateResponse struct {
+	Plan group.Plan
+}
+
+// UpdateGroupRequest is the rpc wrapper for the UpdateGroup method.
+type UpdateGroupRequest struct {
+	Spec group.Spec
+}
+
+// UpdateGroupResponse is the rpc wrapper for the UpdateGroup method.
+type UpdateGroupResponse struct {
+}
+
+// UpdateGroupAsyncRequest is the rpc wrapper for the UpdateGroupAsync method.
+type UpdateGroupAsyncRequest struct {
+	Spec group.Spec
+}
+
+// UpdateGroupAsyncResponse is the rpc wrapper for the UpdateGroupAsync method.
+type UpdateGroupAsyncResponse struct {
+	UpdateID string
+}
+
+// PollUpdateRequest asks the plugin for any UpdateEvents recorded since the given cursor.
+type PollUpdateRequest struct {
+	UpdateID string
+	Since    int
+}
+
+// PollUpdateResponse returns the UpdateEvents recorded since the requested cursor, and whether
+// the update has reached a terminal state.
+type PollUpdateResponse struct {
+	Events []group.UpdateEvent
+	Done   bool
+}
+
+// StopUpdateRequest is the rpc wrapper for the StopUpdate method.
+type StopUpdateRequest struct {
+	ID group.ID
+}
+
+// StopUpdateResponse is the rpc wrapper for the StopUpdate method.
+type StopUpdateResponse struct {
+}
+
+// DestroyGroupRequest is the rpc wrapper for the DestroyGroup method.
+type DestroyGroupRequest struct {
+	ID group.ID
+}
+
+// DestroyGroupResponse is the rpc wrapper for the DestroyGroup method.
+type DestroyGroupResponse struct {
+}
+
+// DescribeGroupsRequest is the rpc wrapper for the DescribeGroups method.
+type DescribeGroupsRequest struct {
+}
+
+// DescribeGroupsResponse is the rpc wrapper for the DescribeGroups method.
+type DescribeGroupsResponse struct {
+	Groups []group.Spec
+}
+
+// GroupEventsRequest is the rpc wrapper for the GroupEvents method.
+type GroupEventsRequest struct {
+	ID   group.ID
+	Opts group.EventsOptions
+}
+
+// GroupEventsResponse is the rpc wrapper for the GroupEvents method.
+type GroupEventsResponse struct {
+	StreamID string
+}
+
+// PollEventsRequest asks the plugin for any group.Events recorded since the given cursor on a
+// stream started by a prior GroupEvents call.
+type PollEventsRequest struct {
+	StreamID string
+	Since    int
+}
+
+// PollEventsResponse returns the group.Events recorded since the requested cursor, and whether
+// the stream has ended.
+type PollEventsResponse struct {
+	Events []group.Event
+	Done   bool
+}
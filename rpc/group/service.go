@@ -0,0 +1,265 @@
+package group
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/docker/infrakit/spi/group"
+)
+
+// PluginServer returns a net/rpc compatible service that dispatches to the given group plugin.
+func PluginServer(plugin group.Plugin) *Group {
+	return &Group{
+		plugin:  plugin,
+		polls:   map[string]*updatePoll{},
+		streams: map[string]*eventStream{},
+	}
+}
+
+// Group is the rpc service wrapping a group.Plugin implementation.  Its exported methods are
+// the RPC endpoints, named Group.<Method> per net/rpc convention.
+type Group struct {
+	plugin group.Plugin
+
+	lock      sync.Mutex
+	polls     map[string]*updatePoll
+	streams   map[string]*eventStream
+	streamNum int
+}
+
+// updatePoll buffers the UpdateEvents delivered on a group.Plugin's WatchUpdate channel so that
+// they can be served to polling rpc clients.
+type updatePoll struct {
+	lock   sync.Mutex
+	events []group.UpdateEvent
+	done   bool
+}
+
+func (p *updatePoll) append(event group.UpdateEvent) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *updatePoll) finish() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.done = true
+}
+
+func (p *updatePoll) since(cursor int) ([]group.UpdateEvent, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cursor >= len(p.events) {
+		return nil, p.done
+	}
+	return p.events[cursor:], p.done
+}
+
+// WatchGroup is the rpc endpoint for group.Plugin.WatchGroup.
+func (g *Group) WatchGroup(req WatchGroupRequest, resp *WatchGroupResponse) error {
+	*resp = WatchGroupResponse{}
+	return g.plugin.WatchGroup(req.Spec)
+}
+
+// UnwatchGroup is the rpc endpoint for group.Plugin.UnwatchGroup.
+func (g *Group) UnwatchGroup(req UnwatchGroupRequest, resp *UnwatchGroupResponse) error {
+	*resp = UnwatchGroupResponse{}
+	return g.plugin.UnwatchGroup(req.ID)
+}
+
+// InspectGroup is the rpc endpoint for group.Plugin.InspectGroup.
+func (g *Group) InspectGroup(req InspectGroupRequest, resp *InspectGroupResponse) error {
+	desc, err := g.plugin.InspectGroup(req.ID)
+	if err != nil {
+		return err
+	}
+	resp.Description = desc
+	return nil
+}
+
+// DescribeUpdate is the rpc endpoint for group.Plugin.DescribeUpdate.
+func (g *Group) DescribeUpdate(req DescribeUpdateRequest, resp *DescribeUpdateResponse) error {
+	plan, err := g.plugin.DescribeUpdate(req.Spec)
+	if err != nil {
+		return err
+	}
+	resp.Plan = plan
+	return nil
+}
+
+// PlanUpdate is the rpc endpoint for group.Plugin.PlanUpdate.
+func (g *Group) PlanUpdate(req PlanUpdateRequest, resp *PlanUpdateResponse) error {
+	plan, err := g.plugin.PlanUpdate(req.Spec)
+	if err != nil {
+		return err
+	}
+	resp.Plan = plan
+	return nil
+}
+
+// UpdateGroup is the rpc endpoint for group.Plugin.UpdateGroup.
+func (g *Group) UpdateGroup(req UpdateGroupRequest, resp *UpdateGroupResponse) error {
+	*resp = UpdateGroupResponse{}
+	return g.plugin.UpdateGroup(req.Spec)
+}
+
+// UpdateGroupAsync is the rpc endpoint for group.Plugin.UpdateGroupAsync.  It also begins
+// buffering the update's events so that PollUpdate can serve them to clients.
+func (g *Group) UpdateGroupAsync(req UpdateGroupAsyncRequest, resp *UpdateGroupAsyncResponse) error {
+	updateID, err := g.plugin.UpdateGroupAsync(req.Spec)
+	if err != nil {
+		return err
+	}
+
+	events, err := g.plugin.WatchUpdate(updateID)
+	if err != nil {
+		return err
+	}
+
+	poll := &updatePoll{}
+	g.lock.Lock()
+	g.polls[updateID] = poll
+	g.lock.Unlock()
+
+	go func() {
+		for event := range events {
+			poll.append(event)
+		}
+		poll.finish()
+	}()
+
+	resp.UpdateID = updateID
+	return nil
+}
+
+// PollUpdate is the rpc endpoint that serves buffered UpdateEvents to a polling client.  Once
+// an update's events have been fully drained and the update has reached a terminal state, its
+// entry is evicted so that g.polls doesn't grow without bound over the life of the process.
+func (g *Group) PollUpdate(req PollUpdateRequest, resp *PollUpdateResponse) error {
+	g.lock.Lock()
+	poll, ok := g.polls[req.UpdateID]
+	g.lock.Unlock()
+
+	if !ok {
+		resp.Done = true
+		return nil
+	}
+
+	events, done := poll.since(req.Since)
+	resp.Events = events
+	resp.Done = done
+
+	if done {
+		g.lock.Lock()
+		delete(g.polls, req.UpdateID)
+		g.lock.Unlock()
+	}
+
+	return nil
+}
+
+// StopUpdate is the rpc endpoint for group.Plugin.StopUpdate.
+func (g *Group) StopUpdate(req StopUpdateRequest, resp *StopUpdateResponse) error {
+	*resp = StopUpdateResponse{}
+	return g.plugin.StopUpdate(req.ID)
+}
+
+// DestroyGroup is the rpc endpoint for group.Plugin.DestroyGroup.
+func (g *Group) DestroyGroup(req DestroyGroupRequest, resp *DestroyGroupResponse) error {
+	*resp = DestroyGroupResponse{}
+	return g.plugin.DestroyGroup(req.ID)
+}
+
+// DescribeGroups is the rpc endpoint for group.Plugin.DescribeGroups.
+func (g *Group) DescribeGroups(req DescribeGroupsRequest, resp *DescribeGroupsResponse) error {
+	groups, err := g.plugin.DescribeGroups()
+	if err != nil {
+		return err
+	}
+	resp.Groups = groups
+	return nil
+}
+
+// eventStream buffers the group.Events delivered on a group.Plugin's GroupEvents channel so
+// that they can be served to polling rpc clients.
+type eventStream struct {
+	lock   sync.Mutex
+	events []group.Event
+	done   bool
+}
+
+func (s *eventStream) append(event group.Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *eventStream) finish() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.done = true
+}
+
+func (s *eventStream) since(cursor int) ([]group.Event, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if cursor >= len(s.events) {
+		return nil, s.done
+	}
+	return s.events[cursor:], s.done
+}
+
+// GroupEvents is the rpc endpoint for group.Plugin.GroupEvents.  It starts buffering the
+// group's events under a new stream ID that PollEvents uses to serve them to the client.
+func (g *Group) GroupEvents(req GroupEventsRequest, resp *GroupEventsResponse) error {
+	events, err := g.plugin.GroupEvents(req.ID, req.Opts)
+	if err != nil {
+		return err
+	}
+
+	stream := &eventStream{}
+
+	g.lock.Lock()
+	g.streamNum++
+	streamID := fmt.Sprintf("%s-%d", req.ID, g.streamNum)
+	g.streams[streamID] = stream
+	g.lock.Unlock()
+
+	go func() {
+		for event := range events {
+			stream.append(event)
+		}
+		stream.finish()
+	}()
+
+	resp.StreamID = streamID
+	return nil
+}
+
+// PollEvents is the rpc endpoint that serves buffered group.Events to a polling client.  Once a
+// stream has been fully drained and the underlying GroupEvents channel has closed (Follow was
+// false, or the group was unwatched), its entry is evicted so that g.streams doesn't grow
+// without bound for a long-lived plugin host serving repeated `group logs` invocations.
+func (g *Group) PollEvents(req PollEventsRequest, resp *PollEventsResponse) error {
+	g.lock.Lock()
+	stream, ok := g.streams[req.StreamID]
+	g.lock.Unlock()
+
+	if !ok {
+		resp.Done = true
+		return nil
+	}
+
+	events, done := stream.since(req.Since)
+	resp.Events = events
+	resp.Done = done
+
+	if done {
+		g.lock.Lock()
+		delete(g.streams, req.StreamID)
+		g.lock.Unlock()
+	}
+
+	return nil
+}
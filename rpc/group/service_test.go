@@ -0,0 +1,190 @@
+package group
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/docker/infrakit/spi/group"
+)
+
+func TestUpdatePollSinceReturnsOnlyNewEvents(t *testing.T) {
+	poll := &updatePoll{}
+
+	poll.append(group.UpdateEvent{Message: "a"})
+	poll.append(group.UpdateEvent{Message: "b"})
+
+	events, done := poll.since(0)
+	if len(events) != 2 || done {
+		t.Fatalf("expected 2 events and not done, got %d events, done=%v", len(events), done)
+	}
+
+	events, done = poll.since(2)
+	if len(events) != 0 || done {
+		t.Fatalf("expected no new events and not done, got %d events, done=%v", len(events), done)
+	}
+
+	poll.finish()
+
+	events, done = poll.since(2)
+	if len(events) != 0 || !done {
+		t.Fatalf("expected no new events and done, got %d events, done=%v", len(events), done)
+	}
+}
+
+func TestUpdatePollConcurrentAppendAndSince(t *testing.T) {
+	poll := &updatePoll{}
+	const n = 100
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			poll.append(group.UpdateEvent{Message: "event"})
+		}
+		poll.finish()
+	}()
+
+	go func() {
+		defer wg.Done()
+		cursor := 0
+		for {
+			events, done := poll.since(cursor)
+			cursor += len(events)
+			if done {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	events, done := poll.since(0)
+	if len(events) != n || !done {
+		t.Fatalf("expected %d events and done, got %d events, done=%v", n, len(events), done)
+	}
+}
+
+func TestPollUpdateEvictsEntryOnceDone(t *testing.T) {
+	g := PluginServer(nil)
+
+	poll := &updatePoll{}
+	poll.append(group.UpdateEvent{Message: "done"})
+	poll.finish()
+	g.polls["u1"] = poll
+
+	resp := PollUpdateResponse{}
+	if err := g.PollUpdate(PollUpdateRequest{UpdateID: "u1", Since: 0}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 1 || !resp.Done {
+		t.Fatalf("expected 1 event and done, got %d events, done=%v", len(resp.Events), resp.Done)
+	}
+
+	g.lock.Lock()
+	_, stillThere := g.polls["u1"]
+	g.lock.Unlock()
+	if stillThere {
+		t.Error("expected the finished poll to be evicted from g.polls")
+	}
+
+	// Polling an evicted (or unknown) update ID reports done rather than erroring.
+	resp = PollUpdateResponse{}
+	if err := g.PollUpdate(PollUpdateRequest{UpdateID: "u1", Since: 1}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Done {
+		t.Error("expected polling an evicted update ID to report done")
+	}
+}
+
+func TestPollUpdateDoesNotEvictWhileInProgress(t *testing.T) {
+	g := PluginServer(nil)
+
+	poll := &updatePoll{}
+	poll.append(group.UpdateEvent{Message: "in progress"})
+	g.polls["u1"] = poll
+
+	resp := PollUpdateResponse{}
+	if err := g.PollUpdate(PollUpdateRequest{UpdateID: "u1", Since: 0}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Done {
+		t.Error("expected an unfinished poll to report not done")
+	}
+
+	g.lock.Lock()
+	_, stillThere := g.polls["u1"]
+	g.lock.Unlock()
+	if !stillThere {
+		t.Error("expected the unfinished poll to remain in g.polls")
+	}
+}
+
+func TestEventStreamConcurrentAppendAndSince(t *testing.T) {
+	stream := &eventStream{}
+	const n = 100
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			stream.append(group.Event{Message: "event"})
+		}
+		stream.finish()
+	}()
+
+	go func() {
+		defer wg.Done()
+		cursor := 0
+		for {
+			events, done := stream.since(cursor)
+			cursor += len(events)
+			if done {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	events, done := stream.since(0)
+	if len(events) != n || !done {
+		t.Fatalf("expected %d events and done, got %d events, done=%v", n, len(events), done)
+	}
+}
+
+func TestPollEventsEvictsEntryOnceDone(t *testing.T) {
+	g := PluginServer(nil)
+
+	stream := &eventStream{}
+	stream.append(group.Event{Message: "done"})
+	stream.finish()
+	g.streams["s1"] = stream
+
+	resp := PollEventsResponse{}
+	if err := g.PollEvents(PollEventsRequest{StreamID: "s1", Since: 0}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 1 || !resp.Done {
+		t.Fatalf("expected 1 event and done, got %d events, done=%v", len(resp.Events), resp.Done)
+	}
+
+	g.lock.Lock()
+	_, stillThere := g.streams["s1"]
+	g.lock.Unlock()
+	if stillThere {
+		t.Error("expected the drained stream to be evicted from g.streams")
+	}
+
+	resp = PollEventsResponse{}
+	if err := g.PollEvents(PollEventsRequest{StreamID: "s1", Since: 1}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Done {
+		t.Error("expected polling an evicted stream ID to report done")
+	}
+}
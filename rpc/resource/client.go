@@ -0,0 +1,54 @@
+package resource
+
+import (
+	"net/rpc"
+
+	"github.com/docker/infrakit/spi/resource"
+)
+
+// NewClient returns a resource.Plugin backed by a resource plugin listening at the given
+// address.
+func NewClient(protocol, address string) (resource.Plugin, error) {
+	rpcClient, err := rpc.Dial(protocol, address)
+	if err != nil {
+		return nil, err
+	}
+	return &client{client: rpcClient}, nil
+}
+
+type client struct {
+	client *rpc.Client
+}
+
+func (c client) Commit(spec resource.Spec) (resource.ID, error) {
+	req := CommitRequest{Spec: spec}
+	resp := CommitResponse{}
+	if err := c.client.Call("Resource.Commit", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (c client) Describe(id resource.ID) (resource.Description, error) {
+	req := DescribeRequest{ID: id}
+	resp := DescribeResponse{}
+	if err := c.client.Call("Resource.Describe", req, &resp); err != nil {
+		return resource.Description{}, err
+	}
+	return resp.Description, nil
+}
+
+func (c client) DescribeAll() ([]resource.Description, error) {
+	req := DescribeAllRequest{}
+	resp := DescribeAllResponse{}
+	if err := c.client.Call("Resource.DescribeAll", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Descriptions, nil
+}
+
+func (c client) Destroy(id resource.ID) error {
+	req := DestroyRequest{ID: id}
+	resp := DestroyResponse{}
+	return c.client.Call("Resource.Destroy", req, &resp)
+}
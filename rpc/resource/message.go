@@ -0,0 +1,41 @@
+package resource
+
+import "github.com/docker/infrakit/spi/resource"
+
+// CommitRequest is the rpc wrapper for the Commit method.
+type CommitRequest struct {
+	Spec resource.Spec
+}
+
+// CommitResponse is the rpc wrapper for the Commit method.
+type CommitResponse struct {
+	ID resource.ID
+}
+
+// DescribeRequest is the rpc wrapper for the Describe method.
+type DescribeRequest struct {
+	ID resource.ID
+}
+
+// DescribeResponse is the rpc wrapper for the Describe method.
+type DescribeResponse struct {
+	Description resource.Description
+}
+
+// DescribeAllRequest is the rpc wrapper for the DescribeAll method.
+type DescribeAllRequest struct {
+}
+
+// DescribeAllResponse is the rpc wrapper for the DescribeAll method.
+type DescribeAllResponse struct {
+	Descriptions []resource.Description
+}
+
+// DestroyRequest is the rpc wrapper for the Destroy method.
+type DestroyRequest struct {
+	ID resource.ID
+}
+
+// DestroyResponse is the rpc wrapper for the Destroy method.
+type DestroyResponse struct {
+}
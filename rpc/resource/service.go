@@ -0,0 +1,51 @@
+package resource
+
+import "github.com/docker/infrakit/spi/resource"
+
+// PluginServer returns a net/rpc compatible service that dispatches to the given resource
+// plugin.
+func PluginServer(plugin resource.Plugin) *Resource {
+	return &Resource{plugin: plugin}
+}
+
+// Resource is the rpc service wrapping a resource.Plugin implementation.  Its exported methods
+// are the RPC endpoints, named Resource.<Method> per net/rpc convention.
+type Resource struct {
+	plugin resource.Plugin
+}
+
+// Commit is the rpc endpoint for resource.Plugin.Commit.
+func (r *Resource) Commit(req CommitRequest, resp *CommitResponse) error {
+	id, err := r.plugin.Commit(req.Spec)
+	if err != nil {
+		return err
+	}
+	resp.ID = id
+	return nil
+}
+
+// Describe is the rpc endpoint for resource.Plugin.Describe.
+func (r *Resource) Describe(req DescribeRequest, resp *DescribeResponse) error {
+	desc, err := r.plugin.Describe(req.ID)
+	if err != nil {
+		return err
+	}
+	resp.Description = desc
+	return nil
+}
+
+// DescribeAll is the rpc endpoint for resource.Plugin.DescribeAll.
+func (r *Resource) DescribeAll(req DescribeAllRequest, resp *DescribeAllResponse) error {
+	descriptions, err := r.plugin.DescribeAll()
+	if err != nil {
+		return err
+	}
+	resp.Descriptions = descriptions
+	return nil
+}
+
+// Destroy is the rpc endpoint for resource.Plugin.Destroy.
+func (r *Resource) Destroy(req DestroyRequest, resp *DestroyResponse) error {
+	*resp = DestroyResponse{}
+	return r.plugin.Destroy(req.ID)
+}
@@ -0,0 +1,65 @@
+package group
+
+import "time"
+
+// EventType categorizes the kind of activity a group.Event reports on.
+type EventType string
+
+const (
+	// EventScaleUp indicates the controller decided to provision additional instances.
+	EventScaleUp EventType = "scale-up"
+
+	// EventScaleDown indicates the controller decided to remove excess instances.
+	EventScaleDown EventType = "scale-down"
+
+	// EventReplace indicates the controller decided to replace an instance, e.g. because its
+	// configuration is out of date.
+	EventReplace EventType = "replace"
+
+	// EventInstanceError indicates the instance plugin returned an error provisioning,
+	// destroying, or describing an instance.  The rpc client also sends it, as the last event
+	// on the channel, when polling the group plugin for events fails.
+	EventInstanceError EventType = "instance-error"
+
+	// EventFlavorPrepare indicates the flavor plugin is preparing an instance's configuration
+	// before it is submitted to the instance plugin.
+	EventFlavorPrepare EventType = "flavor-prepare"
+
+	// EventFlavorHealthy indicates the flavor plugin reported a health check transition for
+	// an instance.
+	EventFlavorHealthy EventType = "flavor-healthy"
+)
+
+// Event is a single log entry describing convergence decisions and per-instance provisioning
+// activity for a group, used by GroupEvents to let operators debug why a group isn't
+// converging without shelling into the plugin host.
+type Event struct {
+	// Time is when the event occurred.
+	Time time.Time
+
+	// GroupID is the group the event pertains to.
+	GroupID ID
+
+	// Type is the kind of event.
+	Type EventType
+
+	// InstanceID identifies the instance the event pertains to, if any.
+	InstanceID string
+
+	// Message is a short human-readable description of the event.
+	Message string
+
+	// Error is set when the event reports a failure, e.g. EventInstanceError.
+	Error string
+}
+
+// EventsOptions controls which events GroupEvents returns.
+type EventsOptions struct {
+	// Since restricts the backlog to events at or after this time.  The zero value means no
+	// backlog -- only events that occur after the call are returned.
+	Since time.Time
+
+	// Follow keeps the returned channel open and streams new events as they occur.  When
+	// false, the channel is closed once the backlog has been delivered.
+	Follow bool
+}
@@ -0,0 +1,85 @@
+package group
+
+import (
+	"encoding/json"
+
+	"github.com/docker/infrakit/spi/instance"
+	"github.com/docker/infrakit/spi/resource"
+)
+
+// ID is the identifier for a group.
+type ID string
+
+// ResourceRef identifies a shared resource, declared and materialized by the resources
+// subsystem, that a group's instances depend on.
+type ResourceRef struct {
+	// ID is the identifier of the resource, as committed via the resource plugin.
+	ID resource.ID
+}
+
+// Spec is the specification for a group of instances.
+type Spec struct {
+	// ID is the unique identifier for the group.
+	ID ID
+
+	// Properties is the opaque configuration for the group, interpreted by the group plugin.
+	Properties *json.RawMessage
+
+	// Depends lists the shared resources this group's instances depend on.  WatchGroup
+	// blocks provisioning group instances until every dependency reaches resource.Ready.
+	Depends []ResourceRef
+}
+
+// Description is a report of the instances that belong to a group.
+type Description struct {
+	// Instances are the instances that are currently part of the group.
+	Instances []instance.Description
+}
+
+// Plugin defines the functions for a Group plugin.  A group plugin can manage and monitor
+// a collection of instances, using flavor and instance plugins for the actual lifecycle work.
+type Plugin interface {
+	// WatchGroup begins monitoring a group.  The plugin maintains the group in accordance
+	// with the spec.  If the spec declares Depends, WatchGroup blocks until every dependency
+	// is Ready before provisioning any group instance.
+	WatchGroup(grp Spec) error
+
+	// UnwatchGroup stops monitoring a group.
+	UnwatchGroup(id ID) error
+
+	// InspectGroup returns the current state of a group being watched.
+	InspectGroup(id ID) (Description, error)
+
+	// DescribeUpdate returns a human-readable plan for updating a group to a new spec.
+	DescribeUpdate(updated Spec) (string, error)
+
+	// PlanUpdate returns a structured diff of the changes an update to the new spec would
+	// make, without making any changes, so that callers can review or gate on it before
+	// calling UpdateGroup or UpdateGroupAsync.
+	PlanUpdate(updated Spec) (Plan, error)
+
+	// UpdateGroup updates a group to the new spec, blocking until the update completes.
+	UpdateGroup(updated Spec) error
+
+	// UpdateGroupAsync starts an update of the group and returns an identifier that can be
+	// used with WatchUpdate to observe progress, without blocking until completion.
+	UpdateGroupAsync(updated Spec) (updateID string, err error)
+
+	// WatchUpdate streams UpdateEvents for the given update ID.  The channel is closed once
+	// the update reaches a terminal state (completed, failed, or stopped).
+	WatchUpdate(updateID string) (<-chan UpdateEvent, error)
+
+	// StopUpdate stops any pending update on the group.
+	StopUpdate(id ID) error
+
+	// DestroyGroup destroys a group, terminating all instances that belong to it.
+	DestroyGroup(id ID) error
+
+	// DescribeGroups lists the groups currently being watched.
+	DescribeGroups() ([]Spec, error)
+
+	// GroupEvents streams convergence decisions and per-instance provisioning activity for a
+	// group being watched.  The channel is closed when the backlog has been delivered and
+	// opts.Follow is false, or when the group is unwatched.
+	GroupEvents(id ID, opts EventsOptions) (<-chan Event, error)
+}
@@ -0,0 +1,39 @@
+package group
+
+// ChangeType describes what will happen to an instance as part of a planned update.
+type ChangeType string
+
+const (
+	// ChangeAdd indicates a new instance will be provisioned.
+	ChangeAdd ChangeType = "add"
+
+	// ChangeRemove indicates an existing instance will be destroyed without replacement.
+	ChangeRemove ChangeType = "remove"
+
+	// ChangeReplace indicates an existing instance will be destroyed and a replacement
+	// provisioned in its place.
+	ChangeReplace ChangeType = "replace"
+)
+
+// InstanceChange describes the effect an update will have on a single instance.
+type InstanceChange struct {
+	// InstanceID identifies the existing instance being removed or replaced.  It is empty
+	// for a ChangeAdd, since the instance does not yet exist.
+	InstanceID string
+
+	// Change is the kind of change that will be made.
+	Change ChangeType
+
+	// Reason is a short human-readable explanation, e.g. "init script hash changed" or
+	// "flavor version bump".
+	Reason string
+}
+
+// Plan is the structured result of planning an update to a group, without making any changes.
+type Plan struct {
+	// GroupID is the group the plan applies to.
+	GroupID ID
+
+	// Changes enumerates the effect on every instance the update will touch.
+	Changes []InstanceChange
+}
@@ -0,0 +1,41 @@
+package group
+
+// UpdateEventType categorizes the lifecycle stage a group update event reports on.
+type UpdateEventType string
+
+const (
+	// UpdateEventProvisioning indicates a replacement instance is being provisioned.
+	UpdateEventProvisioning UpdateEventType = "provisioning"
+
+	// UpdateEventReplaced indicates an instance has been replaced.
+	UpdateEventReplaced UpdateEventType = "replaced"
+
+	// UpdateEventDrained indicates an instance has been drained and is ready for removal.
+	UpdateEventDrained UpdateEventType = "drained"
+
+	// UpdateEventFailed indicates the update, or a step of it, has failed.  The rpc client
+	// also sends it, as the last event on the channel, when polling the group plugin for the
+	// update's progress fails.
+	UpdateEventFailed UpdateEventType = "failed"
+
+	// UpdateEventRolledBack indicates an instance was rolled back after a failed update.
+	UpdateEventRolledBack UpdateEventType = "rolled-back"
+
+	// UpdateEventCompleted indicates the update has finished successfully.
+	UpdateEventCompleted UpdateEventType = "completed"
+)
+
+// UpdateEvent reports the progress of a single step of a group update.
+type UpdateEvent struct {
+	// Type is the kind of event being reported.
+	Type UpdateEventType
+
+	// InstanceID identifies the instance the event pertains to, if any.
+	InstanceID string
+
+	// Message is a short human-readable description of the event.
+	Message string
+
+	// Error is set when Type is UpdateEventFailed.
+	Error string
+}
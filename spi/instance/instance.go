@@ -0,0 +1,19 @@
+package instance
+
+// ID is the instance identifier.
+type ID string
+
+// LogicalID is a logical identifier for an instance, such as an IP address, that is stable across replacement.
+type LogicalID string
+
+// Description contains the properties of an instance as reported by the instance plugin.
+type Description struct {
+	// ID is the identifier for the instance.
+	ID ID
+
+	// LogicalID associates a logical identifier with this instance, if any.
+	LogicalID *LogicalID
+
+	// Tags are the metadata associated with the instance.
+	Tags map[string]string
+}
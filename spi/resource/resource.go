@@ -0,0 +1,67 @@
+package resource
+
+import "encoding/json"
+
+// ID is the identifier for a resource.
+type ID string
+
+// State is the lifecycle state of a resource.
+type State string
+
+const (
+	// Pending indicates the resource has been committed but is not yet materialized.
+	Pending State = "pending"
+
+	// Ready indicates the resource has been materialized and can be depended on by groups.
+	Ready State = "ready"
+
+	// Failed indicates materializing the resource failed.
+	Failed State = "failed"
+
+	// Destroying indicates the resource is being torn down.
+	Destroying State = "destroying"
+)
+
+// Spec is the specification for a shared resource, such as a VPC, subnet, load balancer, or
+// IAM role, that is declared once and materialized before any group that depends on it is
+// provisioned.
+type Spec struct {
+	// ID is the unique identifier for the resource.
+	ID ID
+
+	// Properties is the opaque configuration for the resource, interpreted by the plugin.
+	Properties *json.RawMessage
+}
+
+// Description reports the current state of a committed resource.
+type Description struct {
+	// ID is the identifier for the resource.
+	ID ID
+
+	// State is the resource's current lifecycle state.
+	State State
+
+	// Tags are the metadata associated with the resource.
+	Tags map[string]string
+}
+
+// Plugin defines the functions for a resource plugin.  A resource plugin materializes and
+// tears down shared infrastructure that group instances depend on but that is not owned by
+// any single group.
+type Plugin interface {
+	// Commit declares a resource and begins materializing it if it does not already exist.
+	// Committing a resource that already exists with the same Properties is a no-op.
+	Commit(spec Spec) (ID, error)
+
+	// Describe returns the current state of a committed resource.
+	Describe(id ID) (Description, error)
+
+	// DescribeAll lists all resources known to the plugin.
+	DescribeAll() ([]Description, error)
+
+	// Destroy tears down a resource.  A resource plugin has no visibility into which groups
+	// declare it in their Spec.Depends, so it cannot enforce this itself -- callers (such as
+	// the CLI's "resource destroy" command) must check against the group plugin's
+	// DescribeGroups and refuse to destroy a resource that any watched group still depends on.
+	Destroy(id ID) error
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Diff returns a unified-style, line-based diff between the canonical JSON of two manifests'
+// specs.  It is intended for human review on the CLI, not as a machine-readable patch format.
+func Diff(from, to Manifest) (string, error) {
+	fromLines, err := indentedLines(from)
+	if err != nil {
+		return "", err
+	}
+
+	toLines, err := indentedLines(to)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s (rev %d)\n", from.Digest, from.Sequence)
+	fmt.Fprintf(&out, "+++ %s (rev %d)\n", to.Digest, to.Sequence)
+
+	max := len(fromLines)
+	if len(toLines) > max {
+		max = len(toLines)
+	}
+
+	for i := 0; i < max; i++ {
+		var fromLine, toLine string
+		if i < len(fromLines) {
+			fromLine = fromLines[i]
+		}
+		if i < len(toLines) {
+			toLine = toLines[i]
+		}
+
+		switch {
+		case fromLine == toLine:
+			fmt.Fprintf(&out, "  %s\n", fromLine)
+		case fromLine == "":
+			fmt.Fprintf(&out, "+ %s\n", toLine)
+		case toLine == "":
+			fmt.Fprintf(&out, "- %s\n", fromLine)
+		default:
+			fmt.Fprintf(&out, "- %s\n", fromLine)
+			fmt.Fprintf(&out, "+ %s\n", toLine)
+		}
+	}
+
+	return out.String(), nil
+}
+
+func indentedLines(manifest Manifest) ([]string, error) {
+	buff, err := json.MarshalIndent(manifest.Spec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(buff), "\n"), nil
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/docker/infrakit/spi/group"
+)
+
+// digestOf returns the content digest of a group.Spec's canonical JSON encoding.
+func digestOf(spec group.Spec) (Digest, error) {
+	canonical, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return Digest("sha256:" + hex.EncodeToString(sum[:])), nil
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/docker/infrakit/spi/group"
+)
+
+// NewFileStore returns a Store that persists manifests as JSON files under dir, one
+// subdirectory per group ID, named by revision sequence number.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+type fileStore struct {
+	dir string
+
+	lock sync.Mutex
+}
+
+func (s *fileStore) groupDir(id group.ID) string {
+	return filepath.Join(s.dir, string(id))
+}
+
+func (s *fileStore) manifestPath(id group.ID, sequence int) string {
+	return filepath.Join(s.groupDir(id), fmt.Sprintf("%d.json", sequence))
+}
+
+// maxSaveAttempts bounds the retry loop in Save against the rare case where two processes
+// race to claim the same sequence number.
+const maxSaveAttempts = 10
+
+func (s *fileStore) Save(spec group.Spec) (Manifest, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	digest, err := digestOf(spec)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	if err := os.MkdirAll(s.groupDir(spec.ID), 0700); err != nil {
+		return Manifest{}, err
+	}
+
+	for attempt := 0; attempt < maxSaveAttempts; attempt++ {
+		history, err := s.history(spec.ID)
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		if len(history) > 0 && history[len(history)-1].Digest == digest {
+			return history[len(history)-1], nil
+		}
+
+		manifest := Manifest{
+			Digest:   digest,
+			GroupID:  spec.ID,
+			Sequence: len(history) + 1,
+			Spec:     spec,
+		}
+
+		buff, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		claimed, err := s.claim(spec.ID, manifest.Sequence, buff)
+		if err != nil {
+			return Manifest{}, err
+		}
+		if !claimed {
+			// Another process won this sequence number in the meantime; retry against
+			// the refreshed history rather than silently clobbering its manifest.
+			continue
+		}
+
+		return manifest, nil
+	}
+
+	return Manifest{}, fmt.Errorf("could not claim a revision for group %s after %d attempts", spec.ID, maxSaveAttempts)
+}
+
+// claim atomically writes buff to the manifest path for (id, sequence), but only if that path
+// doesn't already exist.  It writes to a temporary file first and links it into place, so that
+// a concurrent History never observes a manifest file before it is fully written, and so that
+// two racing writers for the same sequence number can't clobber one another -- the loser's link
+// fails with an "already exists" error, reported back as claimed == false.
+func (s *fileStore) claim(id group.ID, sequence int, buff []byte) (claimed bool, err error) {
+	tmp, err := ioutil.TempFile(s.groupDir(id), ".manifest-tmp-")
+	if err != nil {
+		return false, err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(buff); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+
+	if err := os.Link(tmpPath, s.manifestPath(id, sequence)); err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *fileStore) History(id group.ID) ([]Manifest, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.history(id)
+}
+
+func (s *fileStore) history(id group.ID) ([]Manifest, error) {
+	entries, err := ioutil.ReadDir(s.groupDir(id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]Manifest, 0, len(entries))
+	for _, entry := range entries {
+		// Skip the temporary files used while claiming a revision in Save -- only
+		// fully-written "<sequence>.json" manifests belong in the history.
+		if filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		buff, err := ioutil.ReadFile(filepath.Join(s.groupDir(id), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		manifest := Manifest{}
+		if err := json.Unmarshal(buff, &manifest); err != nil {
+			return nil, err
+		}
+		history = append(history, manifest)
+	}
+
+	sort.Sort(bySequence(history))
+
+	return history, nil
+}
+
+type bySequence []Manifest
+
+func (s bySequence) Len() int           { return len(s) }
+func (s bySequence) Less(i, j int) bool { return s[i].Sequence < s[j].Sequence }
+func (s bySequence) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *fileStore) Get(id group.ID, rev string) (Manifest, error) {
+	history, err := s.History(id)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	for _, manifest := range history {
+		if string(manifest.Digest) == rev || fmt.Sprintf("%d", manifest.Sequence) == rev {
+			return manifest, nil
+		}
+	}
+
+	return Manifest{}, fmt.Errorf("no revision %q found for group %s", rev, id)
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"github.com/docker/infrakit/spi/group"
+)
+
+// Digest identifies a group.Spec by the content hash of its canonical JSON encoding, in the
+// form "sha256:<hex>" -- the same scheme used by the plugin and image distribution manifests.
+type Digest string
+
+// Manifest records one revision of a group.Spec in the history of a group.
+type Manifest struct {
+	// Digest is the content address of the Spec.
+	Digest Digest
+
+	// GroupID is the group this revision belongs to.
+	GroupID group.ID
+
+	// Sequence is a monotonically increasing revision number within the group's history,
+	// starting at 1, so that revisions can be referred to positionally (e.g. "rollback 3").
+	Sequence int
+
+	// Spec is the group.Spec snapshotted at this revision.
+	Spec group.Spec
+}
+
+// Store snapshots every group.Spec submitted for a group, keyed by content digest, and keeps
+// an ordered history per group ID so that prior revisions can be listed, diffed, and restored.
+type Store interface {
+	// Save snapshots spec under its content digest and appends it to the group's history.
+	// Saving an unchanged spec (same digest as the current head) is a no-op and returns the
+	// existing manifest.
+	Save(spec group.Spec) (Manifest, error)
+
+	// History returns the manifests recorded for a group, oldest first.
+	History(id group.ID) ([]Manifest, error)
+
+	// Get returns the manifest for a specific revision of a group.  rev may be a digest or a
+	// sequence number formatted as a decimal string.
+	Get(id group.ID, rev string) (Manifest, error)
+}
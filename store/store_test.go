@@ -0,0 +1,225 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/infrakit/spi/group"
+)
+
+func specWith(id group.ID, raw string) group.Spec {
+	msg := json.RawMessage(raw)
+	return group.Spec{ID: id, Properties: &msg}
+}
+
+func tempStore(t *testing.T) (*fileStore, func()) {
+	dir, err := ioutil.TempDir("", "infrakit-store-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s.(*fileStore), func() { os.RemoveAll(dir) }
+}
+
+func TestSaveAppendsRevisions(t *testing.T) {
+	s, cleanup := tempStore(t)
+	defer cleanup()
+
+	groupID := group.ID("web")
+
+	first, err := s.Save(specWith(groupID, `{"size":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Sequence != 1 {
+		t.Errorf("expected sequence 1, got %d", first.Sequence)
+	}
+
+	second, err := s.Save(specWith(groupID, `{"size":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Sequence != 2 {
+		t.Errorf("expected sequence 2, got %d", second.Sequence)
+	}
+
+	history, err := s.History(groupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(history))
+	}
+	if history[0].Sequence != 1 || history[1].Sequence != 2 {
+		t.Errorf("expected revisions in order, got %v", history)
+	}
+}
+
+func TestSaveUnchangedSpecIsNoop(t *testing.T) {
+	s, cleanup := tempStore(t)
+	defer cleanup()
+
+	groupID := group.ID("web")
+	spec := specWith(groupID, `{"size":1}`)
+
+	first, err := s.Save(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := s.Save(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.Sequence != first.Sequence {
+		t.Errorf("saving an unchanged spec should not create a new revision, got sequence %d after %d",
+			second.Sequence, first.Sequence)
+	}
+
+	history, err := s.History(groupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 {
+		t.Errorf("expected 1 revision, got %d", len(history))
+	}
+}
+
+func TestGetByDigestAndSequence(t *testing.T) {
+	s, cleanup := tempStore(t)
+	defer cleanup()
+
+	groupID := group.ID("web")
+	manifest, err := s.Save(specWith(groupID, `{"size":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bySeq, err := s.Get(groupID, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bySeq.Digest != manifest.Digest {
+		t.Errorf("expected digest %s, got %s", manifest.Digest, bySeq.Digest)
+	}
+
+	byDigest, err := s.Get(groupID, string(manifest.Digest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byDigest.Sequence != manifest.Sequence {
+		t.Errorf("expected sequence %d, got %d", manifest.Sequence, byDigest.Sequence)
+	}
+
+	if _, err := s.Get(groupID, "no-such-rev"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	s, cleanup := tempStore(t)
+	defer cleanup()
+
+	groupID := group.ID("web")
+
+	from, err := s.Save(specWith(groupID, `{"size":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to, err := s.Save(specWith(groupID, `{"size":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := Diff(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out, `"size": 1`) || !strings.Contains(out, `"size": 2`) {
+		t.Errorf("expected diff to show the size change, got:\n%s", out)
+	}
+
+	removed, added := false, false
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "- ") && strings.Contains(line, `"size": 1`) {
+			removed = true
+		}
+		if strings.HasPrefix(line, "+ ") && strings.Contains(line, `"size": 2`) {
+			added = true
+		}
+	}
+	if !removed || !added {
+		t.Errorf("expected a removed old size line and an added new size line, got:\n%s", out)
+	}
+}
+
+func TestSaveConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "infrakit-store-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	groupID := group.ID("web")
+	const concurrency = 10
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Each goroutine uses its own Store, as every CLI invocation does.
+			s, err := NewFileStore(dir)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			raw := strings.Replace(`{"size":N}`, "N", string(rune('0'+i)), 1)
+			if _, err := s.Save(specWith(groupID, raw)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := s.History(groupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(history) != concurrency {
+		t.Fatalf("expected %d revisions, got %d -- a concurrent Save likely clobbered another's manifest", concurrency, len(history))
+	}
+
+	seen := map[int]bool{}
+	for _, manifest := range history {
+		if seen[manifest.Sequence] {
+			t.Errorf("duplicate sequence number %d in history", manifest.Sequence)
+		}
+		seen[manifest.Sequence] = true
+	}
+	for i := 1; i <= concurrency; i++ {
+		if !seen[i] {
+			t.Errorf("missing sequence number %d in history", i)
+		}
+	}
+}